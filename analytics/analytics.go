@@ -0,0 +1,23 @@
+// Package analytics provides composable aggregations over parsed
+// Apache/nginx access log entries. A Reducer observes entries one at a
+// time via Add and produces a summary via Result, so many reducers can
+// be driven over the same stream in a single pass instead of looping
+// over the entries once per statistic.
+package analytics
+
+import "github.com/rstnk/go-log-parser/parser"
+
+// Reducer aggregates information across a stream of log entries.
+type Reducer interface {
+	Add(*parser.LogEntry)
+	Result() any
+}
+
+// Run drives entries through every reducer in a single pass.
+func Run(entries []*parser.LogEntry, reducers ...Reducer) {
+	for _, e := range entries {
+		for _, r := range reducers {
+			r.Add(e)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package analytics
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/rstnk/go-log-parser/parser"
+)
+
+func entry(ip, path, method string, status, size int, ts time.Time) *parser.LogEntry {
+	return &parser.LogEntry{
+		IP:           ip,
+		Path:         path,
+		Method:       method,
+		StatusCode:   status,
+		ResponseSize: size,
+		Timestamp:    ts,
+	}
+}
+
+func TestTopN(t *testing.T) {
+	entries := []*parser.LogEntry{
+		entry("1.1.1.1", "/a", "GET", 200, 0, time.Time{}),
+		entry("1.1.1.1", "/a", "GET", 200, 0, time.Time{}),
+		entry("2.2.2.2", "/b", "GET", 200, 0, time.Time{}),
+	}
+
+	r := NewTopIPs(1)
+	Run(entries, r)
+
+	got := r.Result().([]KeyCount)
+	if len(got) != 1 || got[0].Key != "1.1.1.1" || got[0].Count != 2 {
+		t.Errorf("TopIPs(1) = %+v, want [{1.1.1.1 2}]", got)
+	}
+}
+
+func TestTopN_NegativeN(t *testing.T) {
+	entries := []*parser.LogEntry{entry("1.1.1.1", "/a", "GET", 200, 0, time.Time{})}
+
+	r := NewTopIPs(-1)
+	Run(entries, r)
+
+	got := r.Result().([]KeyCount)
+	if len(got) != 0 {
+		t.Errorf("TopIPs(-1).Result() = %+v, want empty slice", got)
+	}
+}
+
+func TestBytesPerIP(t *testing.T) {
+	entries := []*parser.LogEntry{
+		entry("1.1.1.1", "/a", "GET", 200, 100, time.Time{}),
+		entry("1.1.1.1", "/b", "GET", 200, 50, time.Time{}),
+		entry("2.2.2.2", "/c", "GET", 200, 10, time.Time{}),
+	}
+
+	r := NewBytesPerIP()
+	Run(entries, r)
+
+	got := r.Result().(map[string]int)
+	if got["1.1.1.1"] != 150 || got["2.2.2.2"] != 10 {
+		t.Errorf("BytesPerIP = %v, want {1.1.1.1:150 2.2.2.2:10}", got)
+	}
+}
+
+func TestPathErrorRate(t *testing.T) {
+	entries := []*parser.LogEntry{
+		entry("1.1.1.1", "/a", "GET", 200, 0, time.Time{}),
+		entry("1.1.1.1", "/a", "GET", 500, 0, time.Time{}),
+		entry("1.1.1.1", "/b", "GET", 200, 0, time.Time{}),
+	}
+
+	r := NewPathErrorRate()
+	Run(entries, r)
+
+	rates := r.Result().([]PathErrorRate)
+	var gotA *PathErrorRate
+	for i := range rates {
+		if rates[i].Path == "/a" {
+			gotA = &rates[i]
+		}
+	}
+	if gotA == nil {
+		t.Fatal("no error-rate entry for /a")
+	}
+	if gotA.Total != 2 || gotA.Errors != 1 || gotA.Rate != 0.5 {
+		t.Errorf("/a error rate = %+v, want {Total:2 Errors:1 Rate:0.5}", gotA)
+	}
+}
+
+func TestRequestsPerMinute_NormalizesTimeZone(t *testing.T) {
+	utc := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	// Same instant as utc, but parsed with a different *Location, as
+	// happens when access log entries carry different server offsets.
+	other := utc.In(time.FixedZone("", -7*3600))
+
+	r := NewRequestsPerMinute()
+	Run([]*parser.LogEntry{
+		entry("1.1.1.1", "/a", "GET", 200, 0, utc),
+		entry("1.1.1.1", "/a", "GET", 200, 0, other),
+	}, r)
+
+	counts := r.Result().(map[time.Time]int)
+	if len(counts) != 1 {
+		t.Fatalf("counts has %d buckets, want 1 (got %v)", len(counts), counts)
+	}
+	for _, c := range counts {
+		if c != 2 {
+			t.Errorf("bucket count = %d, want 2", c)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	base := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	entries := []*parser.LogEntry{
+		entry("1.1.1.1", "/a", "GET", 500, 0, base),
+		entry("1.1.1.1", "/b", "GET", 200, 0, base),
+		entry("1.1.1.1", "/a", "GET", 500, 0, base.Add(2*time.Hour)),
+	}
+
+	f := &Filter{
+		Reducer:     NewTopPaths(20),
+		Start:       base.Add(-time.Minute),
+		End:         base.Add(time.Hour),
+		StatusClass: 5,
+	}
+	Run(entries, f)
+
+	got := f.Result().([]KeyCount)
+	if len(got) != 1 || got[0].Key != "/a" || got[0].Count != 1 {
+		t.Errorf("filtered TopPaths = %+v, want [{/a 1}]", got)
+	}
+}
+
+func TestFilter_PathRegexAndMethod(t *testing.T) {
+	entries := []*parser.LogEntry{
+		entry("1.1.1.1", "/api/users", "GET", 200, 0, time.Time{}),
+		entry("1.1.1.1", "/api/users", "POST", 200, 0, time.Time{}),
+		entry("1.1.1.1", "/static/a.css", "GET", 200, 0, time.Time{}),
+	}
+
+	f := &Filter{
+		Reducer:   NewTopPaths(20),
+		PathRegex: regexp.MustCompile(`^/api/`),
+		Method:    "GET",
+	}
+	Run(entries, f)
+
+	got := f.Result().([]KeyCount)
+	if len(got) != 1 || got[0].Key != "/api/users" || got[0].Count != 1 {
+		t.Errorf("filtered TopPaths = %+v, want [{/api/users 1}]", got)
+	}
+}
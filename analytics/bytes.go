@@ -0,0 +1,21 @@
+package analytics
+
+import "github.com/rstnk/go-log-parser/parser"
+
+type bytesPerIP struct {
+	bytes map[string]int
+}
+
+// NewBytesPerIP returns a Reducer whose Result is a map[string]int of
+// total response bytes sent per client IP.
+func NewBytesPerIP() Reducer {
+	return &bytesPerIP{bytes: make(map[string]int)}
+}
+
+func (b *bytesPerIP) Add(e *parser.LogEntry) {
+	b.bytes[e.IP] += e.ResponseSize
+}
+
+func (b *bytesPerIP) Result() any {
+	return b.bytes
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/rstnk/go-log-parser/parser"
+)
+
+// PathErrorRate is one entry of a path error-rate result: how many of a
+// path's requests came back 4xx or 5xx.
+type PathErrorRate struct {
+	Path   string
+	Total  int
+	Errors int
+	Rate   float64
+}
+
+type pathErrorRate struct {
+	total  map[string]int
+	errors map[string]int
+}
+
+// NewPathErrorRate returns a Reducer whose Result is a []PathErrorRate,
+// sorted by error rate descending, giving the fraction of each path's
+// requests that returned a 4xx or 5xx status.
+func NewPathErrorRate() Reducer {
+	return &pathErrorRate{total: make(map[string]int), errors: make(map[string]int)}
+}
+
+func (p *pathErrorRate) Add(e *parser.LogEntry) {
+	p.total[e.Path]++
+	if e.StatusCode >= 400 {
+		p.errors[e.Path]++
+	}
+}
+
+func (p *pathErrorRate) Result() any {
+	rates := make([]PathErrorRate, 0, len(p.total))
+	for path, total := range p.total {
+		errs := p.errors[path]
+		rates = append(rates, PathErrorRate{
+			Path:   path,
+			Total:  total,
+			Errors: errs,
+			Rate:   float64(errs) / float64(total),
+		})
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Rate > rates[j].Rate
+	})
+	return rates
+}
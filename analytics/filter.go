@@ -0,0 +1,69 @@
+package analytics
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/rstnk/go-log-parser/parser"
+)
+
+// Filter wraps a Reducer so it only sees entries matching every
+// configured predicate. A zero Filter field means "don't filter on
+// this dimension". For example, the 20 paths with the most 5xx errors
+// between 02:00 and 03:00:
+//
+//	f := &Filter{
+//		Reducer:     NewTopPaths(20),
+//		Start:       start,
+//		End:         end,
+//		StatusClass: 5,
+//	}
+//	Run(entries, f)
+//	top := f.Result().([]KeyCount)
+type Filter struct {
+	Reducer Reducer
+
+	// Start and End bound the entry's Timestamp; the zero time means
+	// unbounded on that side.
+	Start, End time.Time
+
+	// StatusClass restricts entries to a status code class, e.g. 4 for
+	// 4xx or 5 for 5xx. 0 means any status.
+	StatusClass int
+
+	// PathRegex, if set, restricts entries to paths it matches.
+	PathRegex *regexp.Regexp
+
+	// Method, if non-empty, restricts entries to that HTTP method.
+	Method string
+}
+
+func (f *Filter) Add(e *parser.LogEntry) {
+	if !f.matches(e) {
+		return
+	}
+	f.Reducer.Add(e)
+}
+
+func (f *Filter) Result() any {
+	return f.Reducer.Result()
+}
+
+func (f *Filter) matches(e *parser.LogEntry) bool {
+	if !f.Start.IsZero() && e.Timestamp.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && e.Timestamp.After(f.End) {
+		return false
+	}
+	if f.StatusClass != 0 && e.StatusCode/100 != f.StatusClass {
+		return false
+	}
+	if f.PathRegex != nil && !f.PathRegex.MatchString(e.Path) {
+		return false
+	}
+	if f.Method != "" && e.Method != f.Method {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/rstnk/go-log-parser/parser"
+)
+
+type histogram struct {
+	bucket time.Duration
+	counts map[time.Time]int
+}
+
+// NewRequestsPerMinute returns a Reducer whose Result is a
+// map[time.Time]int of request counts bucketed by the minute each
+// request's timestamp falls into.
+func NewRequestsPerMinute() Reducer {
+	return newHistogram(time.Minute)
+}
+
+// NewRequestsPerHour is the hourly counterpart of NewRequestsPerMinute.
+func NewRequestsPerHour() Reducer {
+	return newHistogram(time.Hour)
+}
+
+func newHistogram(bucket time.Duration) Reducer {
+	return &histogram{bucket: bucket, counts: make(map[time.Time]int)}
+}
+
+func (h *histogram) Add(e *parser.LogEntry) {
+	// Normalize to UTC before truncating: two time.Time values for the
+	// same instant but built from different *Location values compare
+	// unequal with ==, which is what map keys use, so mixed server
+	// offsets would otherwise fragment a bucket into several.
+	h.counts[e.Timestamp.UTC().Truncate(h.bucket)]++
+}
+
+func (h *histogram) Result() any {
+	return h.counts
+}
@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/rstnk/go-log-parser/parser"
+)
+
+// KeyCount is one entry of a TopN result: a key (IP, path, user agent,
+// ...) and how many times it was seen.
+type KeyCount struct {
+	Key   string
+	Count int
+}
+
+type topN struct {
+	n      int
+	key    func(*parser.LogEntry) string
+	counts map[string]int
+}
+
+// NewTopIPs returns a Reducer whose Result is the n most frequent
+// client IPs, as a []KeyCount sorted by Count descending.
+func NewTopIPs(n int) Reducer {
+	return newTopN(n, func(e *parser.LogEntry) string { return e.IP })
+}
+
+// NewTopPaths returns a Reducer whose Result is the n most frequently
+// requested paths.
+func NewTopPaths(n int) Reducer {
+	return newTopN(n, func(e *parser.LogEntry) string { return e.Path })
+}
+
+// NewTopUserAgents returns a Reducer whose Result is the n most
+// frequent User-Agent strings.
+func NewTopUserAgents(n int) Reducer {
+	return newTopN(n, func(e *parser.LogEntry) string { return e.UserAgent })
+}
+
+func newTopN(n int, key func(*parser.LogEntry) string) Reducer {
+	if n < 0 {
+		n = 0
+	}
+	return &topN{n: n, key: key, counts: make(map[string]int)}
+}
+
+func (t *topN) Add(e *parser.LogEntry) {
+	t.counts[t.key(e)]++
+}
+
+func (t *topN) Result() any {
+	items := make([]KeyCount, 0, len(t.counts))
+	for k, c := range t.counts {
+		items = append(items, KeyCount{Key: k, Count: c})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Count > items[j].Count
+	})
+
+	if len(items) > t.n {
+		items = items[:t.n]
+	}
+	return items
+}
@@ -1,30 +1,17 @@
 package main
 
 import (
-	"bufio"
 	"cmp"
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
-	"regexp"
 	"slices"
-	"strconv"
-	"time"
-)
 
-type LogEntry struct {
-	IP           string
-	User         string
-	Timestamp    time.Time
-	Method       string
-	Path         string
-	Protocol     string
-	StatusCode   int
-	ResponseSize int
-	Referer      string
-	UserAgent    string
-}
+	"github.com/rstnk/go-log-parser/parser"
+)
 
 type StatusCodeCount struct {
 	Code  int
@@ -32,9 +19,19 @@ type StatusCodeCount struct {
 }
 
 func main() {
+	format := flag.String("format", "", `output format for stdin input: "ndjson" or "csv"`)
+	flag.Parse()
+
+	if *format != "" {
+		if err := writeFormatted(*format); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	logPath := filepath.Clean("testdata/access.log")
 
-	logs, err := logReader(logPath)
+	logs, err := parser.ReadApacheLog(logPath)
 	if err != nil {
 		log.Fatal("failed to read log:", err)
 	}
@@ -50,90 +47,46 @@ func main() {
 	}
 }
 
-// logParser parses a single Apache access log line and returns a LogEntry
-// struct with the extracted fields. It uses regex to match the expected
-// log format and converts timestamp, status code, and response size to
-// their appropriate types. Returns an error if the line doesn't match
-// the expected format or if type conversion fails.
-func logParser(l string) (*LogEntry, error) {
-	var entry *LogEntry
-
-	re := regexp.MustCompile(`^(\d+\.\d+\.\d+\.\d+) - (\S+) \[([^\]]+)\] "(\S+) ([^\s]+) (\S+)" (\d+) (\d+) "([^"]*)" "([^"]*)"$`)
-	s := re.FindStringSubmatch(l)
-	if s == nil {
-		return nil, fmt.Errorf("failed to parse line")
-	}
-
-	// Datetime conversion
-	timeLayout := "02/Jan/2006:15:04:05 -0700"
-	timeString := s[3]
-	t, err := time.Parse(timeLayout, timeString)
-	if err != nil {
-		return nil, err
-	}
-
-	// Int conversion
-	statusInt, err := strconv.Atoi(s[7])
-	if err != nil {
-		return nil, err
-	}
-	responseString, err := strconv.Atoi(s[8])
-	if err != nil {
-		return nil, err
-	}
+// writeFormatted streams Apache/nginx access log lines from stdin and
+// writes them to stdout in the given format, so the binary can act as a
+// pipeline stage, e.g. `go-log-parser -format=ndjson < access.log`.
+func writeFormatted(format string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	entry = &LogEntry{
-		IP:           s[1],
-		User:         s[2],
-		Timestamp:    t,
-		Method:       s[4],
-		Path:         s[5],
-		Protocol:     s[6],
-		StatusCode:   statusInt,
-		ResponseSize: responseString,
-		Referer:      s[9],
-		UserAgent:    s[10],
-	}
-	return entry, nil
-}
+	entries, errs := parser.StreamLogs(ctx, os.Stdin)
 
-// logReader reads a log file and parses each line into LogEntry structs.
-// It takes a file path as input and returns a slice of parsed log entries
-// and any error encountered during reading or parsing. Lines that fail to
-// parse are skipped with an error message printed to stdout.
-func logReader(path string) ([]*LogEntry, error) {
-	// Open the file
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	// Read the file
-	var logs []*LogEntry
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		// Parse each line
-		parsedln, err := logParser(scanner.Text())
-		if err != nil {
-			fmt.Println("failed to parse:", err)
-			continue
+	errsDone := make(chan struct{})
+	go func() {
+		defer close(errsDone)
+		for err := range errs {
+			fmt.Fprintln(os.Stderr, "failed to parse:", err)
 		}
-
-		// Collect the results
-		logs = append(logs, parsedln)
+	}()
+
+	var err error
+	switch format {
+	case "ndjson":
+		err = parser.WriteNDJSON(os.Stdout, entries)
+	case "csv":
+		err = parser.WriteCSV(os.Stdout, entries)
+	default:
+		err = fmt.Errorf("unknown format %q", format)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	// Unblock the producer goroutine if it exits early (unknown format,
+	// or a write error with entries still pending) so it can close
+	// entries/errs instead of hanging on a send.
+	cancel()
+	for range entries {
 	}
-
-	return logs, nil
+	<-errsDone
+	return err
 }
 
 // statusCodeCounts returns a slice of StatusCodeCount struct,
 // sorted by count.
-func statusCodeCounts(l []*LogEntry) []StatusCodeCount {
+func statusCodeCounts(l []*parser.LogEntry) []StatusCodeCount {
 	m := make(map[int]int)
 	for _, entry := range l {
 		m[entry.StatusCode]++
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+func withStdin(t *testing.T, data string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	orig := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = orig })
+}
+
+func TestWriteFormatted(t *testing.T) {
+	const line = `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a HTTP/1.0" 200 10 "-" "-"` + "\n"
+
+	for _, format := range []string{"ndjson", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			withStdin(t, line)
+
+			outR, outW, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			origStdout := os.Stdout
+			os.Stdout = outW
+			t.Cleanup(func() { os.Stdout = origStdout })
+
+			done := make(chan error, 1)
+			go func() {
+				err := writeFormatted(format)
+				outW.Close()
+				done <- err
+			}()
+
+			out, _ := io.ReadAll(outR)
+			if err := <-done; err != nil {
+				t.Fatalf("writeFormatted(%q): %v", format, err)
+			}
+			if len(out) == 0 {
+				t.Errorf("writeFormatted(%q) produced no output", format)
+			}
+		})
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		withStdin(t, line)
+
+		if err := writeFormatted("bogus"); err == nil {
+			t.Fatal("writeFormatted(\"bogus\"): expected error, got nil")
+		}
+	})
+}
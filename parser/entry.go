@@ -0,0 +1,51 @@
+package parser
+
+import "time"
+
+const commonLogFields = `(?P<ip>\d+\.\d+\.\d+\.\d+) - (?P<user>\S+) \[(?P<timestamp>[^\]]+)\] "(?P<method>\S+) (?P<path>\S+) (?P<protocol>\S+)" (?P<status>\d+) (?P<size>\d+)`
+
+// CommonLogFormat matches the NCSA Common Log Format, e.g.:
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache.gif HTTP/1.0" 200 2326
+const CommonLogFormat = `^` + commonLogFields + `$`
+
+// CombinedLogFormat extends CommonLogFormat with the Referer and
+// User-Agent fields used by Apache's and nginx's "combined" log format.
+const CombinedLogFormat = `^` + commonLogFields + ` "(?P<referer>[^"]*)" "(?P<useragent>[^"]*)"$`
+
+// LogEntry holds the fields extracted from a single Apache/nginx access
+// log line in Combined Log Format. It is a plain tagged struct; all
+// parsing is driven by Parser via the `match` tags below, so LogEntry is
+// no different from any other format a caller might define.
+type LogEntry struct {
+	IP           string    `match:"ip" json:"ip"`
+	User         string    `match:"user" json:"user"`
+	Timestamp    time.Time `match:"timestamp" time:"02/Jan/2006:15:04:05 -0700" json:"timestamp"`
+	Method       string    `match:"method" json:"method"`
+	Path         string    `match:"path" json:"path"`
+	Protocol     string    `match:"protocol" json:"protocol"`
+	StatusCode   int       `match:"status" json:"status_code"`
+	ResponseSize int       `match:"size" json:"response_size"`
+	Referer      string    `match:"referer" json:"referer"`
+	UserAgent    string    `match:"useragent" json:"user_agent"`
+}
+
+var apacheParser = mustParser(CombinedLogFormat)
+
+func mustParser(pattern string) *Parser {
+	p, err := NewParser(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// ParseApacheLine parses a single Apache/nginx access log line in
+// Combined Log Format into a LogEntry.
+func ParseApacheLine(line string) (*LogEntry, error) {
+	entry := &LogEntry{}
+	if err := apacheParser.ParseInto(line, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// WriteNDJSON reads entries until the channel is closed and writes one
+// JSON object per line (newline-delimited JSON) to w, so the output can
+// feed jq, DuckDB, ClickHouse, or a SIEM. Timestamps are encoded in
+// RFC3339Nano, time.Time's default JSON representation.
+func WriteNDJSON[T any](w io.Writer, entries <-chan *T) error {
+	enc := json.NewEncoder(w)
+	for entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV reads entries until the channel is closed and writes them to
+// w as CSV, with a header row derived from the entry type's field names
+// (using each field's `json` tag when present, so columns line up with
+// WriteNDJSON's keys). time.Time fields are rendered as RFC3339Nano.
+func WriteCSV[T any](w io.Writer, entries <-chan *T) error {
+	cw := csv.NewWriter(w)
+
+	var fields []reflect.StructField
+
+	for entry := range entries {
+		v := reflect.ValueOf(entry).Elem()
+
+		if fields == nil {
+			t := v.Type()
+			fields = make([]reflect.StructField, t.NumField())
+			header := make([]string, t.NumField())
+			for i := 0; i < t.NumField(); i++ {
+				fields[i] = t.Field(i)
+				header[i] = csvHeaderName(t.Field(i))
+			}
+			if err := cw.Write(header); err != nil {
+				return err
+			}
+		}
+
+		row := make([]string, len(fields))
+		for i, f := range fields {
+			row[i] = csvValue(v.FieldByIndex(f.Index))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvHeaderName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return field.Name
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func csvValue(fv reflect.Value) string {
+	if t, ok := fv.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%v", fv.Interface())
+}
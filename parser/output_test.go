@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	entries := make(chan *LogEntry, 2)
+	entries <- &LogEntry{
+		IP:         "127.0.0.1",
+		Path:       "/a",
+		StatusCode: 200,
+		Timestamp:  time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+	entries <- &LogEntry{
+		IP:         "127.0.0.2",
+		Path:       "/b",
+		StatusCode: 404,
+		Timestamp:  time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC),
+	}
+	close(entries)
+
+	var buf bytes.Buffer
+	if err := WriteNDJSON(&buf, entries); err != nil {
+		t.Fatalf("WriteNDJSON: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+
+	var first map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("unmarshal first line: %v", err)
+	}
+
+	if first["ip"] != "127.0.0.1" || first["status_code"].(float64) != 200 {
+		t.Errorf("unexpected first entry: %v", first)
+	}
+
+	wantTS := "2024-01-02T03:04:05.000000006Z"
+	if first["timestamp"] != wantTS {
+		t.Errorf("timestamp = %v, want %v", first["timestamp"], wantTS)
+	}
+}
+
+func TestWriteNDJSON_EmptyChannel(t *testing.T) {
+	entries := make(chan *LogEntry)
+	close(entries)
+
+	var buf bytes.Buffer
+	if err := WriteNDJSON(&buf, entries); err != nil {
+		t.Fatalf("WriteNDJSON: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for an empty channel, got %q", buf.String())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	entries := make(chan *LogEntry, 1)
+	entries <- &LogEntry{
+		IP:         "127.0.0.1",
+		User:       "frank",
+		Timestamp:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Method:     "GET",
+		Path:       "/a",
+		Protocol:   "HTTP/1.0",
+		StatusCode: 200,
+	}
+	close(entries)
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, entries); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	r := csv.NewReader(strings.NewReader(buf.String()))
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("reading back CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records (header+rows), want 2: %v", len(records), records)
+	}
+
+	header := records[0]
+	wantHeader := []string{"ip", "user", "timestamp", "method", "path", "protocol", "status_code", "response_size", "referer", "user_agent"}
+	if len(header) != len(wantHeader) {
+		t.Fatalf("header = %v, want %v", header, wantHeader)
+	}
+	for i, name := range wantHeader {
+		if header[i] != name {
+			t.Errorf("header[%d] = %q, want %q (CSV header must match LogEntry's json tags)", i, header[i], name)
+		}
+	}
+
+	row := records[1]
+	tsIdx := 2
+	if row[tsIdx] != "2024-01-02T03:04:05Z" {
+		t.Errorf("timestamp column = %q, want RFC3339Nano %q", row[tsIdx], "2024-01-02T03:04:05Z")
+	}
+}
+
+func TestWriteCSV_EmptyChannel(t *testing.T) {
+	entries := make(chan *LogEntry)
+	close(entries)
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, entries); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output (not even a header) for an empty channel, got %q", buf.String())
+	}
+}
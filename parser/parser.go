@@ -0,0 +1,126 @@
+// Package parser provides a reusable, reflection-driven log line parser.
+// A Parser matches lines against a regular expression with named capture
+// groups and decodes the results into any struct whose fields carry
+// `match:"groupname"` tags, so new log formats can be supported by
+// defining a tagged struct rather than writing a bespoke parsing
+// function.
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Parser matches log lines against a regular expression with named
+// capture groups and decodes the results into tagged struct fields via
+// ParseInto.
+type Parser struct {
+	re     *regexp.Regexp
+	groups map[string]int
+}
+
+// NewParser compiles pattern and indexes its named capture groups.
+// pattern must contain at least one named subexpression (e.g.
+// "(?P<ip>...)"), since ParseInto has no other way to know which group
+// belongs to which struct field. It returns an error if pattern fails
+// to compile or has no named groups.
+func NewParser(pattern string) (*Parser, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile pattern: %w", err)
+	}
+
+	if re.NumSubexp() == 0 {
+		return nil, fmt.Errorf("pattern has no capture groups: %s", pattern)
+	}
+
+	groups := make(map[string]int, re.NumSubexp())
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = i
+		}
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("pattern has no named capture groups: %s", pattern)
+	}
+
+	return &Parser{re: re, groups: groups}, nil
+}
+
+// ParseInto matches line against the parser's pattern and populates the
+// fields of dst, which must be a non-nil pointer to a struct. Each field
+// tagged `match:"groupname"` is set from the matching named capture
+// group; fields without a match tag are left untouched. time.Time fields
+// additionally require a `time:"<layout>"` tag describing how to parse
+// the matched text. ParseInto returns an error if line doesn't match the
+// pattern, a tagged field has no matching group, or a matched value
+// can't be converted to the field's type.
+func (p *Parser) ParseInto(line string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dst must be a non-nil pointer to a struct")
+	}
+
+	sub := p.re.FindStringSubmatch(line)
+	if sub == nil {
+		return fmt.Errorf("line does not match pattern")
+	}
+
+	elem := v.Elem()
+	t := elem.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		groupName, ok := field.Tag.Lookup("match")
+		if !ok {
+			continue
+		}
+
+		idx, ok := p.groups[groupName]
+		if !ok {
+			return fmt.Errorf("field %s: no capture group named %q", field.Name, groupName)
+		}
+
+		if err := setField(elem.Field(i), field, sub[idx]); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setField(fv reflect.Value, field reflect.StructField, value string) error {
+	if layout, ok := field.Tag.Lookup("time"); ok {
+		if fv.Type() != reflect.TypeOf(time.Time{}) {
+			return fmt.Errorf("time tag on non-time.Time field")
+		}
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field kind %s", fv.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+type taggedEntry struct {
+	IP   string    `match:"ip"`
+	When time.Time `match:"when" time:"2006-01-02"`
+}
+
+func TestParser_ParseInto(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		p, err := NewParser(`^(?P<ip>\S+) (?P<when>\S+)$`)
+		if err != nil {
+			t.Fatalf("NewParser: %v", err)
+		}
+
+		var e taggedEntry
+		if err := p.ParseInto("10.0.0.1 2024-01-02", &e); err != nil {
+			t.Fatalf("ParseInto: %v", err)
+		}
+
+		if e.IP != "10.0.0.1" {
+			t.Errorf("IP = %q, want 10.0.0.1", e.IP)
+		}
+		want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		if !e.When.Equal(want) {
+			t.Errorf("When = %v, want %v", e.When, want)
+		}
+	})
+
+	t.Run("field with no matching group", func(t *testing.T) {
+		p, err := NewParser(`^(?P<ip>\S+)$`)
+		if err != nil {
+			t.Fatalf("NewParser: %v", err)
+		}
+
+		var e taggedEntry
+		if err := p.ParseInto("10.0.0.1", &e); err == nil {
+			t.Fatal("ParseInto: expected error for missing \"when\" group, got nil")
+		}
+	})
+
+	t.Run("bad time layout", func(t *testing.T) {
+		p, err := NewParser(`^(?P<ip>\S+) (?P<when>\S+)$`)
+		if err != nil {
+			t.Fatalf("NewParser: %v", err)
+		}
+
+		var e taggedEntry
+		if err := p.ParseInto("10.0.0.1 not-a-date", &e); err == nil {
+			t.Fatal("ParseInto: expected error for unparseable time, got nil")
+		}
+	})
+
+	t.Run("line does not match", func(t *testing.T) {
+		p, err := NewParser(`^(?P<ip>\d+\.\d+\.\d+\.\d+)$`)
+		if err != nil {
+			t.Fatalf("NewParser: %v", err)
+		}
+
+		var e taggedEntry
+		if err := p.ParseInto("not an ip", &e); err == nil {
+			t.Fatal("ParseInto: expected error for non-matching line, got nil")
+		}
+	})
+}
+
+func TestNewParser_RequiresNamedGroups(t *testing.T) {
+	if _, err := NewParser(`^(\S+)$`); err == nil {
+		t.Fatal("NewParser: expected error for pattern with no named groups, got nil")
+	}
+
+	if _, err := NewParser(`^no groups$`); err == nil {
+		t.Fatal("NewParser: expected error for pattern with no groups, got nil")
+	}
+
+	if _, err := NewParser(`(`); err == nil {
+		t.Fatal("NewParser: expected error for invalid pattern, got nil")
+	}
+}
+
+func TestParseApacheLine(t *testing.T) {
+	line := `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache.gif HTTP/1.0" 200 2326 "-" "Mozilla/5.0"`
+
+	e, err := ParseApacheLine(line)
+	if err != nil {
+		t.Fatalf("ParseApacheLine: %v", err)
+	}
+
+	if e.IP != "127.0.0.1" || e.Path != "/apache.gif" || e.StatusCode != 200 || e.ResponseSize != 2326 {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+
+	wantTime := time.Date(2000, time.October, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600))
+	if !e.Timestamp.Equal(wantTime) {
+		t.Errorf("Timestamp = %v, want %v", e.Timestamp, wantTime)
+	}
+
+	if _, err := ParseApacheLine("not a log line"); err == nil {
+		t.Fatal("ParseApacheLine: expected error for non-matching line, got nil")
+	}
+}
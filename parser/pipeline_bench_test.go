@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// benchLogFile writes n synthetic Combined Log Format lines to a
+// temporary file and returns its path, cleaning up when the benchmark
+// finishes.
+func benchLogFile(b *testing.B, n int) string {
+	b.Helper()
+
+	f, err := os.CreateTemp(b.TempDir(), "access-*.log")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(f, "127.0.0.%d - frank [10/Oct/2000:13:55:36 -0700] \"GET /path/%d HTTP/1.0\" 200 2326 \"-\" \"Mozilla/5.0\"\n", i%255, i)
+	}
+
+	return f.Name()
+}
+
+// BenchmarkReadApacheLog and BenchmarkReadApacheLogConcurrent compare the
+// serial reader against the worker-pool pipeline on a several-hundred-
+// thousand-line access log, where regex matching dominates I/O.
+func BenchmarkReadApacheLog(b *testing.B) {
+	path := benchLogFile(b, 500_000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadApacheLog(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadApacheLogConcurrent(b *testing.B) {
+	path := benchLogFile(b, 500_000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadApacheLogConcurrent(path, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempLog(t *testing.T, lines []string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "access.log")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, l := range lines {
+		if _, err := f.WriteString(l + "\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return path
+}
+
+func TestReadApacheLogConcurrent_MatchesSerial(t *testing.T) {
+	lines := []string{
+		`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a HTTP/1.0" 200 10 "-" "-"`,
+		"bad line one",
+		`127.0.0.1 - frank [10/Oct/2000:13:55:37 -0700] "GET /b HTTP/1.0" 200 20 "-" "-"`,
+		"bad line two",
+		`127.0.0.1 - frank [10/Oct/2000:13:55:38 -0700] "GET /c HTTP/1.0" 200 30 "-" "-"`,
+	}
+	path := writeTempLog(t, lines)
+
+	wantPaths := []string{"/a", "/b", "/c"}
+
+	for _, workers := range []int{0, 1, 4} {
+		serial, err := ReadApacheLog(path)
+		if err != nil {
+			t.Fatalf("ReadApacheLog: %v", err)
+		}
+
+		concurrent, err := ReadApacheLogConcurrent(path, workers)
+		if err != nil {
+			t.Fatalf("ReadApacheLogConcurrent(workers=%d): %v", workers, err)
+		}
+
+		if len(concurrent) != len(serial) {
+			t.Fatalf("workers=%d: got %d entries, serial has %d", workers, len(concurrent), len(serial))
+		}
+
+		for i := range wantPaths {
+			if serial[i].Path != wantPaths[i] {
+				t.Fatalf("serial[%d].Path = %q, want %q", i, serial[i].Path, wantPaths[i])
+			}
+			if concurrent[i].Path != wantPaths[i] {
+				t.Errorf("workers=%d: concurrent[%d].Path = %q, want %q", workers, i, concurrent[i].Path, wantPaths[i])
+			}
+			if concurrent[i].Timestamp != serial[i].Timestamp {
+				t.Errorf("workers=%d: concurrent[%d].Timestamp = %v, want %v", workers, i, concurrent[i].Timestamp, serial[i].Timestamp)
+			}
+		}
+	}
+}
+
+func TestReadApacheLogConcurrent_PreservesOrderUnderManyWorkers(t *testing.T) {
+	lines := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		lines = append(lines, `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /`+string(rune('a'+i%26))+` HTTP/1.0" 200 10 "-" "-"`)
+	}
+	path := writeTempLog(t, lines)
+
+	entries, err := ReadApacheLogConcurrent(path, 8)
+	if err != nil {
+		t.Fatalf("ReadApacheLogConcurrent: %v", err)
+	}
+	if len(entries) != len(lines) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(lines))
+	}
+	for i, e := range entries {
+		want := "/" + string(rune('a'+i%26))
+		if e.Path != want {
+			t.Fatalf("entries[%d].Path = %q, want %q (order not preserved)", i, e.Path, want)
+		}
+	}
+}
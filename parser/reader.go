@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// ReadApacheLog reads path and parses every line as an Apache/nginx
+// access log entry in Combined Log Format. Lines that fail to parse are
+// skipped with an error message printed to stdout.
+func ReadApacheLog(path string) ([]*LogEntry, error) {
+	return readLog(path, ParseApacheLine)
+}
+
+// ReadS3Log reads path and parses every line as an S3 server access log
+// entry. Lines that fail to parse are skipped with an error message
+// printed to stdout.
+func ReadS3Log(path string) ([]*S3LogEntry, error) {
+	return readLog(path, ParseS3Line)
+}
+
+func readLog[T any](path string, parse func(string) (*T, error)) ([]*T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*T
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry, err := parse(scanner.Text())
+		if err != nil {
+			fmt.Println("failed to parse:", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
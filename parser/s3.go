@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// s3LogPattern matches an Amazon S3 server access log line, e.g.:
+//
+//	79a59df900b949e55d96a1e698fbacedfd6e09d98eacf8f8d5218e7cc5c7c99 bucket-name [06/Feb/2019:00:00:38 +0000] 192.0.2.3 arn:aws:iam::123456789012:user/someuser 3E57427F3EXAMPLE REST.GET.VERSIONING - "GET /bucket-name?versioning HTTP/1.1" 200 - 113 - 7 - "-" "S3Console/0.4" -
+//
+// Several numeric fields (BytesSent, ObjectSize, TotalTime,
+// TurnAroundTime) use "-" as a sentinel for "not applicable" rather than
+// 0, so they are parsed by ParseS3Line rather than by the generic
+// Parser, which would fail strconv on "-".
+var s3LogPattern = regexp.MustCompile(
+	`^(\S+) (\S+) \[([^\]]+)\] (\S+) (\S+) (\S+) (\S+) (\S+) "([^"]*)" (\S+) (\S+) (\S+) (\S+) (\S+) (\S+) "([^"]*)" "([^"]*)" (\S+)`,
+)
+
+// S3LogEntry holds the fields extracted from a single Amazon S3 server
+// access log line.
+type S3LogEntry struct {
+	BucketOwner    string
+	Bucket         string
+	Time           time.Time
+	RemoteIP       string
+	Requester      string
+	RequestID      string
+	Operation      string
+	Key            string
+	RequestURI     string
+	HTTPStatus     int
+	ErrorCode      string
+	BytesSent      int
+	ObjectSize     int
+	TotalTime      int
+	TurnAroundTime int
+	Referrer       string
+	UserAgent      string
+	VersionID      string
+}
+
+// ParseS3Line parses a single Amazon S3 server access log line into an
+// S3LogEntry. Numeric fields that are "-" in the source line (meaning
+// "not applicable") are left as 0 rather than treated as a parse error.
+func ParseS3Line(line string) (*S3LogEntry, error) {
+	s := s3LogPattern.FindStringSubmatch(line)
+	if s == nil {
+		return nil, fmt.Errorf("failed to parse S3 log line")
+	}
+
+	t, err := time.Parse("02/Jan/2006:15:04:05 -0700", s[3])
+	if err != nil {
+		return nil, err
+	}
+
+	httpStatus, err := s3Int(s[10])
+	if err != nil {
+		return nil, fmt.Errorf("HTTPStatus: %w", err)
+	}
+	bytesSent, err := s3Int(s[12])
+	if err != nil {
+		return nil, fmt.Errorf("BytesSent: %w", err)
+	}
+	objectSize, err := s3Int(s[13])
+	if err != nil {
+		return nil, fmt.Errorf("ObjectSize: %w", err)
+	}
+	totalTime, err := s3Int(s[14])
+	if err != nil {
+		return nil, fmt.Errorf("TotalTime: %w", err)
+	}
+	turnAroundTime, err := s3Int(s[15])
+	if err != nil {
+		return nil, fmt.Errorf("TurnAroundTime: %w", err)
+	}
+
+	return &S3LogEntry{
+		BucketOwner:    s[1],
+		Bucket:         s[2],
+		Time:           t,
+		RemoteIP:       s[4],
+		Requester:      s[5],
+		RequestID:      s[6],
+		Operation:      s[7],
+		Key:            s[8],
+		RequestURI:     s[9],
+		HTTPStatus:     httpStatus,
+		ErrorCode:      s[11],
+		BytesSent:      bytesSent,
+		ObjectSize:     objectSize,
+		TotalTime:      totalTime,
+		TurnAroundTime: turnAroundTime,
+		Referrer:       s[16],
+		UserAgent:      s[17],
+		VersionID:      s[18],
+	}, nil
+}
+
+// s3Int parses an S3 log numeric field, treating the "-" sentinel S3
+// uses for "not applicable" as 0 instead of a strconv error.
+func s3Int(field string) (int, error) {
+	if field == "-" {
+		return 0, nil
+	}
+	return strconv.Atoi(field)
+}
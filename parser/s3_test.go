@@ -0,0 +1,49 @@
+package parser
+
+import "testing"
+
+func TestParseS3Line(t *testing.T) {
+	t.Run("full fields", func(t *testing.T) {
+		line := `79a59df900b949e55d96a1e698fbacedfd6e09d98eacf8f8d5218e7cc5c7c99 bucket-name [06/Feb/2019:00:00:38 +0000] 192.0.2.3 arn:aws:iam::123456789012:user/someuser 3E57427F3EXAMPLE REST.GET.OBJECT key.txt "GET /bucket-name/key.txt HTTP/1.1" 200 - 1024 2048 12 5 "-" "S3Console/0.4" abc123`
+
+		e, err := ParseS3Line(line)
+		if err != nil {
+			t.Fatalf("ParseS3Line: %v", err)
+		}
+
+		if e.Bucket != "bucket-name" || e.RemoteIP != "192.0.2.3" || e.Operation != "REST.GET.OBJECT" {
+			t.Errorf("unexpected entry: %+v", e)
+		}
+		if e.HTTPStatus != 200 {
+			t.Errorf("HTTPStatus = %d, want 200", e.HTTPStatus)
+		}
+		if e.BytesSent != 1024 || e.ObjectSize != 2048 || e.TotalTime != 12 || e.TurnAroundTime != 5 {
+			t.Errorf("unexpected numeric fields: %+v", e)
+		}
+	})
+
+	t.Run("dash sentinels zero numeric fields", func(t *testing.T) {
+		line := `79a59df900b949e55d96a1e698fbacedfd6e09d98eacf8f8d5218e7cc5c7c99 bucket-name [06/Feb/2019:00:00:38 +0000] 192.0.2.3 arn:aws:iam::123456789012:user/someuser 3E57427F3EXAMPLE REST.GET.VERSIONING - "GET /bucket-name?versioning HTTP/1.1" 200 - 113 - 7 - "-" "S3Console/0.4" -`
+
+		e, err := ParseS3Line(line)
+		if err != nil {
+			t.Fatalf("ParseS3Line: %v", err)
+		}
+
+		if e.ObjectSize != 0 {
+			t.Errorf("ObjectSize = %d, want 0 for \"-\" sentinel", e.ObjectSize)
+		}
+		if e.TurnAroundTime != 0 {
+			t.Errorf("TurnAroundTime = %d, want 0 for \"-\" sentinel", e.TurnAroundTime)
+		}
+		if e.ErrorCode != "-" {
+			t.Errorf("ErrorCode = %q, want \"-\"", e.ErrorCode)
+		}
+	})
+
+	t.Run("non-matching line", func(t *testing.T) {
+		if _, err := ParseS3Line("not an s3 log line"); err == nil {
+			t.Fatal("ParseS3Line: expected error for non-matching line, got nil")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// StreamLogs reads r line by line in a background goroutine and parses
+// each line as an Apache/nginx access log entry, sending the result on
+// the returned channel as soon as it is available. This lets callers
+// process arbitrarily large logs (tail -f, gzip streams, sockets)
+// without buffering the whole file into a slice.
+//
+// Parsing failures are sent on the error channel rather than being
+// swallowed, so callers should drain both channels (e.g. with a select
+// loop) until entries is closed. Both channels are closed once r is
+// exhausted, the scanner fails, or ctx is done.
+func StreamLogs(ctx context.Context, r io.Reader) (<-chan *LogEntry, <-chan error) {
+	return stream(ctx, r, ParseApacheLine)
+}
+
+// StreamS3Logs is the S3 server access log counterpart of StreamLogs.
+func StreamS3Logs(ctx context.Context, r io.Reader) (<-chan *S3LogEntry, <-chan error) {
+	return stream(ctx, r, ParseS3Line)
+}
+
+func stream[T any](ctx context.Context, r io.Reader, parse func(string) (*T, error)) (<-chan *T, <-chan error) {
+	entries := make(chan *T)
+	errs := make(chan error)
+
+	go func() {
+		defer close(entries)
+		defer close(errs)
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			entry, err := parse(scanner.Text())
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return entries, errs
+}
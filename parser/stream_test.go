@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamLogs(t *testing.T) {
+	t.Run("parses valid lines and reports bad ones", func(t *testing.T) {
+		data := `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a HTTP/1.0" 200 10 "-" "-"
+bad line
+127.0.0.1 - frank [10/Oct/2000:13:55:37 -0700] "GET /b HTTP/1.0" 200 20 "-" "-"`
+
+		entries, errs := StreamLogs(context.Background(), strings.NewReader(data))
+
+		var paths []string
+		var errCount int
+		for entries != nil || errs != nil {
+			select {
+			case e, ok := <-entries:
+				if !ok {
+					entries = nil
+					continue
+				}
+				paths = append(paths, e.Path)
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				if err == nil {
+					t.Fatal("received nil error on errs channel")
+				}
+				errCount++
+			}
+		}
+
+		if want := []string{"/a", "/b"}; len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+			t.Errorf("paths = %v, want %v", paths, want)
+		}
+		if errCount != 1 {
+			t.Errorf("errCount = %d, want 1", errCount)
+		}
+	})
+
+	t.Run("stops reading once ctx is cancelled", func(t *testing.T) {
+		line := `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a HTTP/1.0" 200 10 "-" "-"` + "\n"
+		data := strings.Repeat(line, 1000)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		entries, errs := StreamLogs(ctx, strings.NewReader(data))
+
+		// Take exactly one entry, then cancel; both channels must close
+		// instead of leaving the producer goroutine blocked forever.
+		<-entries
+		cancel()
+
+		done := make(chan struct{})
+		go func() {
+			for range entries {
+			}
+			for range errs {
+			}
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("StreamLogs did not close its channels after ctx cancellation")
+		}
+	})
+}